@@ -0,0 +1,25 @@
+package cache
+
+import (
+	cacheClearCmd "github.com/cli/cli/v2/pkg/cmd/cache/clear"
+	cacheSyncCmd "github.com/cli/cli/v2/pkg/cmd/cache/sync"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdCache returns the `gh cache` command, giving users control over the
+// local frecency cache for issues and PRs. The caller is responsible for
+// adding this to the root command (e.g. `rootCmd.AddCommand(cacheCmd.NewCmdCache(f))`
+// in pkg/cmd/root); this package doesn't own that wiring.
+func NewCmdCache(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache <command>",
+		Short: "Manage the local issue/PR cache",
+		Long:  "Work with the local frecency cache for issues and PRs.",
+	}
+
+	cmd.AddCommand(cacheSyncCmd.NewCmdSync(f, nil))
+	cmd.AddCommand(cacheClearCmd.NewCmdClear(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,62 @@
+package clear
+
+import (
+	"fmt"
+
+	"github.com/cli/cli/v2/internal/ghrepo"
+	"github.com/cli/cli/v2/pkg/cmd/frecency"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+type ClearOptions struct {
+	IO       *iostreams.IOStreams
+	BaseRepo func() (ghrepo.Interface, error)
+}
+
+// NewCmdClear returns the `gh cache clear` command, which drops the cached
+// issues/PRs for the current repo.
+func NewCmdClear(f *cmdutil.Factory, runF func(*ClearOptions) error) *cobra.Command {
+	opts := &ClearOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove the local issue/PR cache for the current repo",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.BaseRepo = f.BaseRepo
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return clearRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func clearRun(opts *ClearOptions) error {
+	repo, err := opts.BaseRepo()
+	if err != nil {
+		return err
+	}
+
+	db, err := frecency.NewDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := frecency.ClearRepo(db, ghrepo.FullName(repo)); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	if opts.IO.IsStdoutTTY() {
+		fmt.Fprintf(opts.IO.Out, "%s Cleared cache for %s\n", opts.IO.ColorScheme().SuccessIcon(), ghrepo.FullName(repo))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cli/cli/v2/api"
+	"github.com/cli/cli/v2/internal/ghrepo"
+	"github.com/cli/cli/v2/pkg/cmd/frecency"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+type SyncOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	BaseRepo   func() (ghrepo.Interface, error)
+}
+
+// NewCmdSync returns the `gh cache sync` command, which runs a sync of the
+// frecency cache for the current repo right away. The sync is skipped if the
+// repo was already synced within its refresh interval.
+func NewCmdSync(f *cmdutil.Factory, runF func(*SyncOptions) error) *cobra.Command {
+	opts := &SyncOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Refresh the local issue/PR cache for the current repo",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.BaseRepo = f.BaseRepo
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return syncRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func syncRun(opts *SyncOptions) error {
+	repo, err := opts.BaseRepo()
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return err
+	}
+	client := api.NewClientFromHTTP(httpClient)
+
+	db, err := frecency.NewDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	syncer := frecency.NewSyncer(db, client)
+	if err := syncer.Sync(context.Background(), repo); err != nil {
+		return fmt.Errorf("failed to sync cache: %w", err)
+	}
+
+	if opts.IO.IsStdoutTTY() {
+		fmt.Fprintf(opts.IO.Out, "%s Synced cache for %s\n", opts.IO.ColorScheme().SuccessIcon(), ghrepo.FullName(repo))
+	}
+	return nil
+}
@@ -1,18 +1,22 @@
 package frecency
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"sort"
 	"time"
 
-	"github.com/cli/cli/v2/api"
+	"github.com/cli/cli/v2/internal/ghrepo"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 // stores issue/PR with frecency stats
 type entryWithStats struct {
-	Entry interface{}
-	Stats countEntry
+	Title  string
+	Number int
+	IsPR   bool
+	Stats  countEntry
 }
 
 type countEntry struct {
@@ -20,6 +24,13 @@ type countEntry struct {
 	Count      int
 }
 
+// dbEntry identifies a single cached issue or PR row for update statements.
+type dbEntry struct {
+	Repo   string
+	Number int
+	Stats  countEntry
+}
+
 func updateEntry(db *sql.DB, updated *dbEntry) error {
 	tx, err := db.Begin()
 	if err != nil {
@@ -59,7 +70,7 @@ func insertEntry(db *sql.DB, repoName string, entry *entryWithStats) error {
 		return err
 	}
 
-	stmt, err := tx.Prepare("INSERT INTO issues(title,number,count,lastAccess,repo,isPR) values(?,?,?,?,?,?,?)")
+	stmt, err := tx.Prepare("INSERT INTO issues(title,number,count,lastAccess,repo,isPR) values(?,?,?,?,?,?)")
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -71,7 +82,7 @@ func insertEntry(db *sql.DB, repoName string, entry *entryWithStats) error {
 		entry.Number,
 		entry.Stats.Count,
 		entry.Stats.LastAccess.Unix(),
-		entry.Repo.ID,
+		repoName,
 		entry.IsPR)
 
 	if err != nil {
@@ -88,14 +99,14 @@ func insertRepo(db *sql.DB, repoName string) error {
 		return err
 	}
 
-	stmt, err := tx.Prepare("INSERT INTO repos(name) values(?)")
+	stmt, err := tx.Prepare("INSERT INTO repos(fullName) values(?)")
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
 
 	defer stmt.Close()
-	_, err = stmt.Exec(repo.Name)
+	_, err = stmt.Exec(repoName)
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -105,9 +116,24 @@ func insertRepo(db *sql.DB, repoName string) error {
 	return nil
 }
 
-func repoExists(db *sql.DB, repoName string) (bool, error) {
+// RepoExists reports whether repoName already has a row in the repos table.
+func RepoExists(db *sql.DB, repoName string) (bool, error) {
+	var found int
+	row := db.QueryRow("SELECT 1 FROM repos WHERE fullName = ?", repoName)
+	err := row.Scan(&found)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return false, err
+}
+
+// entryExists reports whether repoName already has a cached row for number.
+func entryExists(db *sql.DB, repoName string, number int, isPR bool) (bool, error) {
 	var found int
-	row := db.QueryRow("SELECT 1 FROM repos WHERE name = ?", repoName)
+	row := db.QueryRow("SELECT 1 FROM issues WHERE repo = ? AND number = ? AND isPR = ?", repoName, number, isPR)
 	err := row.Scan(&found)
 	if err == nil {
 		return true, nil
@@ -129,60 +155,94 @@ func getEntries(db *sql.DB, repoName string, isPR bool) ([]entryWithStats, error
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	var entries []entryWithStats
-	for rows.Next() {
-		var entry entryWithStats
-		if isPR {
-			entry.Entry = api.PullRequest{}
-		} else {
-			entry.Entry = api.Issue{}
-		}
-		var unixTime int64
-		if err := rows.Scan(&entry.Entry.Number, &unixTime, &entry.Stats.Count, &entry.Entry.Title); err != nil {
-			return nil, err
-		}
-		entry.Stats.LastAccess = time.Unix(unixTime, 0)
-		entries = append(entries, entry)
+	return scanEntries(rows, isPR)
+}
+
+// GetFrecent returns the cached issues or PRs for repoName ordered by
+// frecency score (highest first) rather than raw lastAccess, capped at
+// limit entries. If syncer is non-nil and the cache for repoName is stale,
+// a background sync is kicked off so the next call sees fresher data; the
+// current (possibly stale) cache is still returned immediately rather than
+// blocking the read on a live API call.
+func GetFrecent(db *sql.DB, repoName string, isPR bool, limit int, syncer *Syncer) ([]entryWithStats, error) {
+	if syncer != nil {
+		triggerLazySync(syncer, repoName)
+	}
+
+	entries, err := getEntries(db, repoName, isPR)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sort.SliceStable(entries, func(i, j int) bool {
+		return Score(entries[i].Stats, now) > Score(entries[j].Stats, now)
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
 	}
 	return entries, nil
 }
 
-func createTables(db *sql.DB) error {
-	// TODO: repo is identified by "owner/repo",
-	// so renaming and transfering ownership will invalidate the db
-	query := `
-	CREATE TABLE IF NOT EXISTS repos(
-		id INTEGER PRIMARY KEY AUTOINCREMENT, 
- 		fullName TEXT NOT NULL UNIQUE,
-		issuesLastQueried INTEGER,
-		prsLastQueried INTEGER,
-	);
-	
-	CREATE TABLE IF NOT EXISTS issues(
-		id INTEGER PRIMARY KEY AUTOINCREMENT, 
-		title TEXT NOT NULL,
-		number INTEGER NOT NULL,
-		count INTEGER NOT NULL,
-		lastAccess INTEGER NOT NULL,
-		isPR BOOLEAN NOT NULL 
-			CHECK (isPR IN (0,1))
-			DEFAULT 0,
-		repo TEXT NOT NULL,
-		FOREIGN KEY (repo) REFERENCES repo(fullName)
-	);
-
-	CREATE INDEX IF NOT EXISTS 
-	frecent ON issues(lastAccess, count);
-	`
-	tx, err := db.Begin()
+// triggerLazySync kicks off a background Sync for repoName if its cache
+// entry is stale, so readers like GetFrecent never block on a live API call
+// but still converge on fresh data over time. Concurrent triggers for the
+// same repo coalesce via syncer.claim, same as the periodic background sync.
+func triggerLazySync(syncer *Syncer, repoName string) {
+	stale, err := syncer.isStale(repoName)
+	if err != nil || !stale {
+		return
+	}
+
+	repo, err := ghrepo.FromFullName(repoName)
 	if err != nil {
-		return err
+		return
 	}
-	if _, err = tx.Exec(query); err != nil {
+
+	go func() {
+		_ = syncer.Sync(context.Background(), repo)
+	}()
+}
+
+// RecordVisit bumps the count and lastAccess of the cached entry for number
+// so its frecency score reflects the visit. Commands that view or check out
+// an issue or PR (e.g. `gh issue view`, `gh pr view`, `gh pr checkout`) are
+// expected to call this after a successful lookup; none of those commands
+// exist in this package, so wiring them up is left to whoever owns them.
+func RecordVisit(db *sql.DB, repoName string, number int, isPR bool) error {
+	var count int
+	row := db.QueryRow("SELECT count FROM issues WHERE repo = ? AND number = ? AND isPR = ?", repoName, number, isPR)
+	switch err := row.Scan(&count); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
 		return err
 	}
 
-	tx.Commit()
-	return nil
+	return updateEntry(db, &dbEntry{
+		Repo:   repoName,
+		Number: number,
+		Stats: countEntry{
+			Count:      count + 1,
+			LastAccess: time.Now(),
+		},
+	})
+}
+
+// ClearRepo removes all cached issues and PRs for repoName.
+func ClearRepo(db *sql.DB, repoName string) error {
+	_, err := db.Exec("DELETE FROM issues WHERE repo = ?", repoName)
+	return err
+}
+
+// createTables brings a fresh or existing cache database up to the latest
+// schema version.
+//
+// TODO: repo is identified by "owner/repo", so renaming and transfering
+// ownership will invalidate the db
+func createTables(db *sql.DB) error {
+	return migrate(db)
 }
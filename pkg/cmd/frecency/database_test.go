@@ -0,0 +1,118 @@
+package frecency
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, createTables(db))
+	return db
+}
+
+func TestInsertAndGetEntries(t *testing.T) {
+	db := openTestDB(t)
+
+	err := insertEntry(db, "cli/cli", &entryWithStats{
+		Title:  "fix bug",
+		Number: 123,
+		IsPR:   false,
+		Stats: countEntry{
+			Count:      1,
+			LastAccess: time.Unix(1000, 0),
+		},
+	})
+	require.NoError(t, err)
+
+	exists, err := RepoExists(db, "cli/cli")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	entries, err := getEntries(db, "cli/cli", false)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "fix bug", entries[0].Title)
+	require.Equal(t, 123, entries[0].Number)
+	require.Equal(t, 1, entries[0].Stats.Count)
+}
+
+func TestUpdateEntry(t *testing.T) {
+	db := openTestDB(t)
+
+	require.NoError(t, insertEntry(db, "cli/cli", &entryWithStats{
+		Title:  "fix bug",
+		Number: 123,
+		Stats: countEntry{
+			Count:      1,
+			LastAccess: time.Unix(1000, 0),
+		},
+	}))
+
+	require.NoError(t, updateEntry(db, &dbEntry{
+		Repo:   "cli/cli",
+		Number: 123,
+		Stats: countEntry{
+			Count:      5,
+			LastAccess: time.Unix(2000, 0),
+		},
+	}))
+
+	entries, err := getEntries(db, "cli/cli", false)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, 5, entries[0].Stats.Count)
+	require.Equal(t, time.Unix(2000, 0), entries[0].Stats.LastAccess)
+}
+
+func TestRecordVisit(t *testing.T) {
+	db := openTestDB(t)
+
+	require.NoError(t, insertEntry(db, "cli/cli", &entryWithStats{
+		Title:  "fix bug",
+		Number: 123,
+		Stats: countEntry{
+			Count:      1,
+			LastAccess: time.Unix(1000, 0),
+		},
+	}))
+
+	require.NoError(t, RecordVisit(db, "cli/cli", 123, false))
+
+	entries, err := getEntries(db, "cli/cli", false)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, 2, entries[0].Stats.Count)
+
+	// visiting an entry that isn't cached yet is a no-op, not an error
+	require.NoError(t, RecordVisit(db, "cli/cli", 999, false))
+}
+
+func TestGetFrecentWithNilSyncerSkipsLazySync(t *testing.T) {
+	db := openTestDB(t)
+
+	require.NoError(t, insertEntry(db, "cli/cli", &entryWithStats{
+		Title:  "fix bug",
+		Number: 123,
+		Stats: countEntry{
+			Count:      1,
+			LastAccess: time.Unix(1000, 0),
+		},
+	}))
+
+	entries, err := GetFrecent(db, "cli/cli", false, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	db := openTestDB(t)
+	require.NoError(t, migrate(db))
+	require.NoError(t, migrate(db))
+}
@@ -0,0 +1,35 @@
+package frecency
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+)
+
+// dbFileName is the name of the SQLite file the frecency cache is stored
+// in, under the user's cache directory.
+const dbFileName = "frecency.db"
+
+// NewDB opens (creating if necessary) the frecency cache database at the
+// default location and brings its schema up to date.
+func NewDB() (*sql.DB, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	dir = filepath.Join(dir, "gh")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", filepath.Join(dir, dbFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createTables(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,161 @@
+package frecency
+
+import (
+	"context"
+	"time"
+
+	"github.com/cli/cli/v2/api"
+	"github.com/cli/cli/v2/internal/ghrepo"
+)
+
+// fetchPageSize is the `first:` page size used by the sync queries below.
+// fetchUpdatedSince uses it to tell whether a fetch may have more changes
+// sitting beyond the page it got back.
+const fetchPageSize = 100
+
+type updatedNode struct {
+	Number    int
+	Title     string
+	UpdatedAt time.Time
+}
+
+// fetchUpdatedSince fetches issues (or PRs, if isPR) for repo that changed
+// after since, for the Syncer to upsert into the cache. It also returns the
+// oldest updatedAt actually fetched (nodes come back newest-first), so the
+// caller can tell whether it's safe to advance its watermark to now or only
+// as far as the oldest item this page covered.
+func fetchUpdatedSince(ctx context.Context, client *api.Client, repo ghrepo.Interface, isPR bool, since time.Time) (entries []entryWithStats, oldestFetched time.Time, fullPage bool, err error) {
+	var query struct {
+		Repository struct {
+			Issues struct {
+				Nodes []updatedNode
+			}
+			PullRequests struct {
+				Nodes []updatedNode
+			}
+		}
+	}
+
+	variables := map[string]interface{}{
+		"owner": repo.RepoOwner(),
+		"name":  repo.RepoName(),
+		"since": since,
+	}
+
+	queryText := issuesQuery
+	if isPR {
+		queryText = pullRequestsQuery
+	}
+
+	if err := client.GraphQL(repo.RepoHost(), queryText, variables, &query); err != nil {
+		return nil, time.Time{}, false, err
+	}
+
+	nodes := query.Repository.Issues.Nodes
+	if isPR {
+		nodes = query.Repository.PullRequests.Nodes
+	}
+
+	for _, n := range nodes {
+		if !n.UpdatedAt.After(since) {
+			continue
+		}
+		entries = append(entries, entryWithStats{
+			Title:  n.Title,
+			Number: n.Number,
+			IsPR:   isPR,
+			Stats:  countEntry{LastAccess: n.UpdatedAt, Count: 1},
+		})
+	}
+
+	if len(nodes) > 0 {
+		oldestFetched = nodes[len(nodes)-1].UpdatedAt
+	}
+	fullPage = len(nodes) == fetchPageSize
+
+	return entries, oldestFetched, fullPage, nil
+}
+
+type closedNode struct {
+	Number   int
+	Closed   bool
+	ClosedAt time.Time
+}
+
+// fetchClosedBefore returns the numbers of repo's issues/PRs that GitHub
+// reports as closed or merged as of before.
+func fetchClosedBefore(ctx context.Context, client *api.Client, repo ghrepo.Interface, isPR bool, before time.Time) ([]int, error) {
+	var query struct {
+		Repository struct {
+			Issues struct {
+				Nodes []closedNode
+			}
+			PullRequests struct {
+				Nodes []closedNode
+			}
+		}
+	}
+
+	variables := map[string]interface{}{
+		"owner": repo.RepoOwner(),
+		"name":  repo.RepoName(),
+	}
+
+	queryText := closedIssuesQuery
+	if isPR {
+		queryText = mergedPullRequestsQuery
+	}
+
+	if err := client.GraphQL(repo.RepoHost(), queryText, variables, &query); err != nil {
+		return nil, err
+	}
+
+	nodes := query.Repository.Issues.Nodes
+	if isPR {
+		nodes = query.Repository.PullRequests.Nodes
+	}
+
+	var numbers []int
+	for _, n := range nodes {
+		if n.Closed && n.ClosedAt.Before(before) {
+			numbers = append(numbers, n.Number)
+		}
+	}
+	return numbers, nil
+}
+
+const issuesQuery = `
+query RepositoryIssues($owner: String!, $name: String!, $since: DateTime!) {
+	repository(owner: $owner, name: $name) {
+		issues(first: 100, filterBy: {since: $since}, orderBy: {field: UPDATED_AT, direction: DESC}) {
+			nodes { number title updatedAt }
+		}
+	}
+}`
+
+const pullRequestsQuery = `
+query RepositoryPullRequests($owner: String!, $name: String!) {
+	repository(owner: $owner, name: $name) {
+		pullRequests(first: 100, orderBy: {field: UPDATED_AT, direction: DESC}) {
+			nodes { number title updatedAt }
+		}
+	}
+}`
+
+const closedIssuesQuery = `
+query RepositoryClosedIssues($owner: String!, $name: String!) {
+	repository(owner: $owner, name: $name) {
+		issues(first: 100, states: CLOSED, orderBy: {field: UPDATED_AT, direction: DESC}) {
+			nodes { number closed closedAt }
+		}
+	}
+}`
+
+const mergedPullRequestsQuery = `
+query RepositoryMergedPullRequests($owner: String!, $name: String!) {
+	repository(owner: $owner, name: $name) {
+		pullRequests(first: 100, states: [CLOSED, MERGED], orderBy: {field: UPDATED_AT, direction: DESC}) {
+			nodes { number closed closedAt }
+		}
+	}
+}`
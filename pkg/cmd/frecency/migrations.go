@@ -0,0 +1,114 @@
+package frecency
+
+import "database/sql"
+
+// migration moves the cache schema forward by exactly one version so
+// existing user databases can be upgraded in place instead of being
+// recreated whenever a column is added (e.g. state, updatedAt, author).
+type migration struct {
+	version int
+	stmt    string
+	// requiresFTS5 skips the migration (without blocking later ones) on
+	// sqlite3 builds that lack the FTS5 extension.
+	requiresFTS5 bool
+}
+
+var migrations = []migration{
+	{
+		version: 1,
+		stmt: `
+		CREATE TABLE IF NOT EXISTS repos(
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			fullName TEXT NOT NULL UNIQUE,
+			issuesLastQueried INTEGER,
+			prsLastQueried INTEGER
+		);
+
+		CREATE TABLE IF NOT EXISTS issues(
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL,
+			number INTEGER NOT NULL,
+			count INTEGER NOT NULL,
+			lastAccess INTEGER NOT NULL,
+			isPR BOOLEAN NOT NULL
+				CHECK (isPR IN (0,1))
+				DEFAULT 0,
+			repo TEXT NOT NULL,
+			FOREIGN KEY (repo) REFERENCES repos(fullName)
+		);
+
+		CREATE INDEX IF NOT EXISTS frecent ON issues(lastAccess, count);
+		`,
+	},
+	{
+		version:      2,
+		requiresFTS5: true,
+		stmt: `
+		CREATE VIRTUAL TABLE IF NOT EXISTS issues_fts USING fts5(
+			title,
+			content='issues',
+			content_rowid='id'
+		);
+
+		INSERT INTO issues_fts(issues_fts) VALUES('rebuild');
+
+		CREATE TRIGGER IF NOT EXISTS issues_fts_ai AFTER INSERT ON issues BEGIN
+			INSERT INTO issues_fts(rowid, title) VALUES (new.id, new.title);
+		END;
+
+		CREATE TRIGGER IF NOT EXISTS issues_fts_ad AFTER DELETE ON issues BEGIN
+			INSERT INTO issues_fts(issues_fts, rowid, title) VALUES ('delete', old.id, old.title);
+		END;
+
+		CREATE TRIGGER IF NOT EXISTS issues_fts_au AFTER UPDATE ON issues BEGIN
+			INSERT INTO issues_fts(issues_fts, rowid, title) VALUES ('delete', old.id, old.title);
+			INSERT INTO issues_fts(rowid, title) VALUES (new.id, new.title);
+		END;
+		`,
+	},
+}
+
+// migrate brings db's schema up to the latest known version, recording
+// progress in schema_migrations so it can resume from wherever a given
+// user's cache was left off rather than reapplying everything from scratch.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations(version INTEGER NOT NULL)`); err != nil {
+		return err
+	}
+
+	var current int
+	row := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)
+	if err := row.Scan(&current); err != nil {
+		return err
+	}
+
+	fts5 := hasFTS5(db)
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if m.requiresFTS5 && !fts5 {
+			current = m.version
+			continue
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(m.stmt); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations(version) VALUES (?)`, m.version); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		current = m.version
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package frecency
+
+import "time"
+
+// maxScoredVisits caps the number of visits that contribute to a score so
+// that a handful of very recent accesses can't get buried by a long history
+// of stale ones.
+const maxScoredVisits = 10
+
+// recency buckets and their base scores, modeled after Firefox's frecency
+// algorithm: the more recently an issue or PR was touched, the more a visit
+// to it counts.
+var recencyBuckets = []struct {
+	within time.Duration
+	score  float64
+}{
+	{4 * time.Hour, 100},
+	{24 * time.Hour, 80},
+	{3 * 24 * time.Hour, 60},
+	{7 * 24 * time.Hour, 40},
+	{30 * 24 * time.Hour, 20},
+}
+
+const staleScore = 10
+
+// Score computes a frecency score for entry as of now: a recency weight
+// (how long ago it was last accessed) multiplied by a capped visit count.
+// Higher scores should sort first.
+func Score(entry countEntry, now time.Time) float64 {
+	age := now.Sub(entry.LastAccess)
+
+	recency := staleScore
+	for _, bucket := range recencyBuckets {
+		if age < bucket.within {
+			recency = int(bucket.score)
+			break
+		}
+	}
+
+	visits := entry.Count
+	if visits > maxScoredVisits {
+		visits = maxScoredVisits
+	}
+	if visits < 1 {
+		visits = 1
+	}
+
+	return float64(recency) * float64(visits)
+}
@@ -0,0 +1,151 @@
+package frecency
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+)
+
+// searchPoolSize bounds how many FTS/LIKE matches are pulled from SQLite
+// before re-ranking by frecency in Go, so a broad query can't force a full
+// table scan's worth of work through the scorer.
+const searchPoolSize = 200
+
+// hasFTS5 reports whether the linked sqlite3 driver was built with the FTS5
+// extension. Without it, searches fall back to a LIKE-based scan instead.
+// Rows cached before the FTS5 migration ran are indexed by that migration.
+func hasFTS5(db *sql.DB) bool {
+	rows, err := db.Query(`PRAGMA compile_options`)
+	if err != nil {
+		return false
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var opt string
+		if err := rows.Scan(&opt); err != nil {
+			return false
+		}
+		if opt == "ENABLE_FTS5" {
+			return true
+		}
+	}
+	return false
+}
+
+// rankedEntry pairs a cached entry with its text-relevance rank from the
+// candidate query, so it can be blended with frecency before the final sort.
+type rankedEntry struct {
+	entry entryWithStats
+	// textRank is a bm25 rank (lower is more relevant) when FTS5 produced the
+	// candidate pool, or 0 when the LIKE fallback was used (no text ranking
+	// available, so every row is treated as equally relevant).
+	textRank float64
+}
+
+// SearchEntries returns cached issues or PRs for repoName whose title
+// matches query, ranked by a combination of text relevance and frecency.
+// It uses FTS5 when available, and falls back to a LIKE scan otherwise.
+func SearchEntries(db *sql.DB, repoName, query string, isPR bool, limit int) ([]entryWithStats, error) {
+	var ranked []rankedEntry
+	var err error
+	if hasFTS5(db) {
+		ranked, err = searchFTS5(db, repoName, query, isPR)
+	} else {
+		ranked, err = searchLike(db, repoName, query, isPR)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return blendedRank(ranked[i], now) > blendedRank(ranked[j], now)
+	})
+
+	entries := make([]entryWithStats, len(ranked))
+	for i, r := range ranked {
+		entries[i] = r.entry
+	}
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// blendedRank combines frecency with text relevance: frecency dominates (it
+// already spans roughly 10-1000), with the bm25 rank subtracted in as a
+// tie-breaker so a stronger title match outranks a marginally fresher one.
+// bm25 is more-negative-is-better in SQLite, so we negate it before mixing.
+func blendedRank(r rankedEntry, now time.Time) float64 {
+	return Score(r.entry.Stats, now) - r.textRank
+}
+
+func searchFTS5(db *sql.DB, repoName, query string, isPR bool) ([]rankedEntry, error) {
+	rows, err := db.Query(`
+		SELECT i.number, i.lastAccess, i.count, i.title, bm25(issues_fts)
+		FROM issues_fts
+		JOIN issues i ON i.id = issues_fts.rowid
+		WHERE issues_fts MATCH ? AND i.repo = ? AND i.isPR = ?
+		ORDER BY bm25(issues_fts)
+		LIMIT ?`,
+		query, repoName, isPR, searchPoolSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRankedEntries(rows, isPR, true)
+}
+
+func searchLike(db *sql.DB, repoName, query string, isPR bool) ([]rankedEntry, error) {
+	rows, err := db.Query(`
+		SELECT number, lastAccess, count, title
+		FROM issues
+		WHERE repo = ? AND isPR = ? AND title LIKE ?
+		ORDER BY lastAccess DESC
+		LIMIT ?`,
+		repoName, isPR, "%"+strings.ReplaceAll(query, "%", "")+"%", searchPoolSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRankedEntries(rows, isPR, false)
+}
+
+func scanRankedEntries(rows *sql.Rows, isPR, withBM25 bool) ([]rankedEntry, error) {
+	var ranked []rankedEntry
+	for rows.Next() {
+		var entry entryWithStats
+		entry.IsPR = isPR
+		var unixTime int64
+		var bm25 float64
+		if withBM25 {
+			if err := rows.Scan(&entry.Number, &unixTime, &entry.Stats.Count, &entry.Title, &bm25); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := rows.Scan(&entry.Number, &unixTime, &entry.Stats.Count, &entry.Title); err != nil {
+				return nil, err
+			}
+		}
+		entry.Stats.LastAccess = time.Unix(unixTime, 0)
+		ranked = append(ranked, rankedEntry{entry: entry, textRank: bm25})
+	}
+	return ranked, rows.Err()
+}
+
+func scanEntries(rows *sql.Rows, isPR bool) ([]entryWithStats, error) {
+	ranked, err := scanRankedEntries(rows, isPR, false)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]entryWithStats, len(ranked))
+	for i, r := range ranked {
+		entries[i] = r.entry
+	}
+	return entries, nil
+}
@@ -0,0 +1,28 @@
+package frecency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchEntries(t *testing.T) {
+	db := openTestDB(t)
+
+	require.NoError(t, insertEntry(db, "cli/cli", &entryWithStats{
+		Title:  "fix flaky login test",
+		Number: 1,
+		Stats:  countEntry{Count: 1, LastAccess: time.Unix(1000, 0)},
+	}))
+	require.NoError(t, insertEntry(db, "cli/cli", &entryWithStats{
+		Title:  "add dark mode",
+		Number: 2,
+		Stats:  countEntry{Count: 1, LastAccess: time.Unix(2000, 0)},
+	}))
+
+	entries, err := SearchEntries(db, "cli/cli", "login", false, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "fix flaky login test", entries[0].Title)
+}
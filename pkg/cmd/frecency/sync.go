@@ -0,0 +1,366 @@
+package frecency
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cli/cli/v2/api"
+	"github.com/cli/cli/v2/internal/ghrepo"
+)
+
+// defaultMinRefreshInterval is the minimum time between syncs for a single
+// repo. It is jittered per repo, by an amount derived from the repo's name,
+// so that many cached repos don't all come due for a refresh at once.
+const defaultMinRefreshInterval = 5 * time.Minute
+
+// defaultRetentionWindow is how long a closed or merged issue/PR stays in
+// the cache after GitHub reports it closed (its closedAt), before being
+// pruned.
+const defaultRetentionWindow = 30 * 24 * time.Hour
+
+// defaultMinScore is the frecency score below which a cached entry is
+// pruned as part of Sync, independent of whether it's closed/merged. It
+// sits just above staleScore so an entry only survives being both stale
+// *and* barely visited if it was visited enough times to push its score up.
+const defaultMinScore = 2 * staleScore
+
+// Syncer keeps the frecency cache reconciled with GitHub in the background.
+type Syncer struct {
+	DB     *sql.DB
+	Client *api.Client
+
+	MinRefreshInterval time.Duration
+	RetentionWindow    time.Duration
+	MinScore           float64
+
+	mu       sync.Mutex
+	inFlight map[string]*syncResult
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewSyncer returns a Syncer ready to sync repos into db using client.
+func NewSyncer(db *sql.DB, client *api.Client) *Syncer {
+	return &Syncer{
+		DB:                 db,
+		Client:             client,
+		MinRefreshInterval: defaultMinRefreshInterval,
+		RetentionWindow:    defaultRetentionWindow,
+		MinScore:           defaultMinScore,
+		inFlight:           map[string]*syncResult{},
+	}
+}
+
+// syncResult is shared between the leader performing a repo's sync and any
+// callers that coalesce onto it, so coalesced callers can observe whether
+// the sync actually succeeded instead of assuming it did.
+type syncResult struct {
+	done chan struct{}
+	err  error
+}
+
+// Start launches a background goroutine that periodically syncs every repo
+// recorded in the repos table (including repos whose entries were cleared),
+// until Stop is called or ctx is done.
+func (s *Syncer) Start(ctx context.Context) {
+	s.stop = make(chan struct{})
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.refreshInterval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				_ = s.syncCachedRepos(ctx)
+			}
+		}
+	}()
+}
+
+// Stop halts the background sync loop started by Start and waits for it to
+// finish.
+func (s *Syncer) Stop() {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// Sync performs an incremental sync of repo's issues and PRs, fetching only
+// what changed since the last queried timestamp, and prunes entries that
+// have been closed or merged for longer than RetentionWindow. It does
+// nothing if repo was synced within its refresh interval. Concurrent
+// calls for the same repo coalesce into a single sync.
+func (s *Syncer) Sync(ctx context.Context, repo ghrepo.Interface) error {
+	repoName := ghrepo.FullName(repo)
+
+	result, leader := s.claim(repoName)
+	if !leader {
+		<-result.done
+		return result.err
+	}
+
+	result.err = s.doSync(ctx, repo, repoName)
+	close(result.done)
+
+	s.mu.Lock()
+	delete(s.inFlight, repoName)
+	s.mu.Unlock()
+
+	return result.err
+}
+
+func (s *Syncer) doSync(ctx context.Context, repo ghrepo.Interface, repoName string) error {
+	stale, err := s.isStale(repoName)
+	if err != nil {
+		return err
+	}
+	if !stale {
+		return nil
+	}
+
+	if err := s.syncKind(ctx, repo, false); err != nil {
+		return err
+	}
+	if err := s.syncKind(ctx, repo, true); err != nil {
+		return err
+	}
+	if err := s.pruneClosed(ctx, repo); err != nil {
+		return err
+	}
+	return s.pruneStale(repo)
+}
+
+// claim registers the calling goroutine as the leader for repoName if no
+// sync is already in flight for it, returning the shared syncResult and
+// whether this call is the leader. Non-leaders should wait on result.done
+// and then read result.err to learn the leader's outcome.
+func (s *Syncer) claim(repoName string) (*syncResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if result, ok := s.inFlight[repoName]; ok {
+		return result, false
+	}
+
+	result := &syncResult{done: make(chan struct{})}
+	s.inFlight[repoName] = result
+	return result, true
+}
+
+// refreshInterval returns the base interval, jittered once at random, used
+// to pace the background sync loop's ticker. It isn't tied to a specific
+// repo, so a fresh roll each call is fine here.
+func (s *Syncer) refreshInterval() time.Duration {
+	interval := s.MinRefreshInterval
+	if interval <= 0 {
+		interval = defaultMinRefreshInterval
+	}
+	jitter := time.Duration(rand.Int63n(int64(interval) / 4))
+	return interval + jitter
+}
+
+// refreshIntervalFor returns the jittered refresh interval for repoName.
+// The jitter is derived deterministically from repoName, rather than
+// re-rolled on every call, so repeated staleness checks for the same repo
+// agree on the same threshold.
+func (s *Syncer) refreshIntervalFor(repoName string) time.Duration {
+	interval := s.MinRefreshInterval
+	if interval <= 0 {
+		interval = defaultMinRefreshInterval
+	}
+
+	quarter := int64(interval) / 4
+	if quarter <= 0 {
+		return interval
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(repoName))
+	jitter := time.Duration(int64(h.Sum64() % uint64(quarter)))
+	return interval + jitter
+}
+
+func (s *Syncer) isStale(repoName string) (bool, error) {
+	var lastQueried int64
+	row := s.DB.QueryRow("SELECT COALESCE(MAX(issuesLastQueried, prsLastQueried), 0) FROM repos WHERE fullName = ?", repoName)
+	switch err := row.Scan(&lastQueried); {
+	case err == sql.ErrNoRows:
+		return true, nil
+	case err != nil:
+		return false, err
+	}
+	return time.Since(time.Unix(lastQueried, 0)) >= s.refreshIntervalFor(repoName), nil
+}
+
+// syncKind fetches issues (isPR=false) or PRs (isPR=true) for repo that
+// changed since the stored watermark, upserting each into the cache.
+func (s *Syncer) syncKind(ctx context.Context, repo ghrepo.Interface, isPR bool) error {
+	repoName := ghrepo.FullName(repo)
+	since, err := s.lastQueried(repoName, isPR)
+	if err != nil {
+		return err
+	}
+
+	updated, oldestFetched, fullPage, err := fetchUpdatedSince(ctx, s.Client, repo, isPR, since)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range updated {
+		exists, err := entryExists(s.DB, repoName, entry.Number, isPR)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if err := updateEntry(s.DB, &dbEntry{Repo: repoName, Number: entry.Number, Stats: entry.Stats}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := insertEntry(s.DB, repoName, &entry); err != nil {
+			return err
+		}
+	}
+
+	// A full page means there may be more changes older than the oldest
+	// item we actually fetched but still newer than `since`. Advancing the
+	// watermark past those would skip them forever, so only jump to now
+	// when the page wasn't full; otherwise re-check from the oldest item we
+	// saw (upserts are idempotent, so re-fetching it is harmless).
+	newWatermark := time.Now()
+	if fullPage {
+		newWatermark = oldestFetched
+	}
+	return s.setLastQueried(repoName, isPR, newWatermark)
+}
+
+func (s *Syncer) lastQueried(repoName string, isPR bool) (time.Time, error) {
+	column := "issuesLastQueried"
+	if isPR {
+		column = "prsLastQueried"
+	}
+
+	var unixTime sql.NullInt64
+	row := s.DB.QueryRow("SELECT "+column+" FROM repos WHERE fullName = ?", repoName)
+	switch err := row.Scan(&unixTime); {
+	case err == sql.ErrNoRows:
+		return time.Time{}, nil
+	case err != nil:
+		return time.Time{}, err
+	}
+	if !unixTime.Valid {
+		return time.Time{}, nil
+	}
+	return time.Unix(unixTime.Int64, 0), nil
+}
+
+func (s *Syncer) setLastQueried(repoName string, isPR bool, at time.Time) error {
+	column := "issuesLastQueried"
+	if isPR {
+		column = "prsLastQueried"
+	}
+	_, err := s.DB.Exec("UPDATE repos SET "+column+" = ? WHERE fullName = ?", at.Unix(), repoName)
+	return err
+}
+
+// pruneClosed removes cached issues and PRs for repo that the API reports as
+// closed or merged for longer than RetentionWindow.
+func (s *Syncer) pruneClosed(ctx context.Context, repo ghrepo.Interface) error {
+	repoName := ghrepo.FullName(repo)
+	cutoff := time.Now().Add(-s.retentionWindow())
+
+	for _, isPR := range []bool{false, true} {
+		closedBefore, err := fetchClosedBefore(ctx, s.Client, repo, isPR, cutoff)
+		if err != nil {
+			return err
+		}
+		for _, number := range closedBefore {
+			if _, err := s.DB.Exec("DELETE FROM issues WHERE repo = ? AND number = ? AND isPR = ?", repoName, number, isPR); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Syncer) retentionWindow() time.Duration {
+	if s.RetentionWindow <= 0 {
+		return defaultRetentionWindow
+	}
+	return s.RetentionWindow
+}
+
+// pruneStale removes cached issues and PRs for repo whose frecency score has
+// fallen below MinScore, e.g. entries that are both old and rarely visited,
+// regardless of whether GitHub still considers them open.
+func (s *Syncer) pruneStale(repo ghrepo.Interface) error {
+	repoName := ghrepo.FullName(repo)
+	now := time.Now()
+	minScore := s.minScore()
+
+	for _, isPR := range []bool{false, true} {
+		entries, err := getEntries(s.DB, repoName, isPR)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if Score(entry.Stats, now) >= minScore {
+				continue
+			}
+			if _, err := s.DB.Exec("DELETE FROM issues WHERE repo = ? AND number = ? AND isPR = ?", repoName, entry.Number, isPR); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Syncer) minScore() float64 {
+	if s.MinScore <= 0 {
+		return defaultMinScore
+	}
+	return s.MinScore
+}
+
+func (s *Syncer) syncCachedRepos(ctx context.Context) error {
+	rows, err := s.DB.Query("SELECT fullName FROM repos")
+	if err != nil {
+		return err
+	}
+	var repoNames []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		repoNames = append(repoNames, name)
+	}
+	rows.Close()
+
+	for _, name := range repoNames {
+		repo, err := ghrepo.FromFullName(name)
+		if err != nil {
+			continue
+		}
+		if err := s.Sync(ctx, repo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package frecency
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cli/cli/v2/internal/ghrepo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncerClaimCoalesces(t *testing.T) {
+	s := NewSyncer(nil, nil)
+
+	result, leader := s.claim("cli/cli")
+	require.True(t, leader)
+
+	_, leader2 := s.claim("cli/cli")
+	require.False(t, leader2, "a second claim for the same repo should not become leader")
+
+	close(result.done)
+}
+
+func TestSyncerClaimSharesLeaderError(t *testing.T) {
+	s := NewSyncer(nil, nil)
+
+	result, leader := s.claim("cli/cli")
+	require.True(t, leader)
+
+	waiter, leader2 := s.claim("cli/cli")
+	require.False(t, leader2)
+
+	result.err = errors.New("boom")
+	close(result.done)
+
+	<-waiter.done
+	require.Equal(t, result.err, waiter.err, "a coalesced caller must see the leader's error, not a hardcoded nil")
+}
+
+func TestSyncerRefreshInterval(t *testing.T) {
+	s := NewSyncer(nil, nil)
+	s.MinRefreshInterval = time.Minute
+
+	interval := s.refreshInterval()
+	require.GreaterOrEqual(t, interval, time.Minute)
+	require.Less(t, interval, time.Minute+time.Minute/4)
+}
+
+func TestSyncerPruneStaleRemovesLowScoringEntries(t *testing.T) {
+	db := openTestDB(t)
+	s := NewSyncer(db, nil)
+
+	require.NoError(t, insertEntry(db, "cli/cli", &entryWithStats{
+		Title:  "ancient, barely visited",
+		Number: 1,
+		Stats: countEntry{
+			Count:      1,
+			LastAccess: time.Now().Add(-60 * 24 * time.Hour),
+		},
+	}))
+	require.NoError(t, insertEntry(db, "cli/cli", &entryWithStats{
+		Title:  "fresh",
+		Number: 2,
+		Stats: countEntry{
+			Count:      1,
+			LastAccess: time.Now(),
+		},
+	}))
+
+	require.NoError(t, s.pruneStale(ghrepo.New("cli", "cli")))
+
+	entries, err := getEntries(db, "cli/cli", false)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, 2, entries[0].Number)
+}
+
+func TestSyncerRefreshIntervalForIsStablePerRepo(t *testing.T) {
+	s := NewSyncer(nil, nil)
+	s.MinRefreshInterval = time.Minute
+
+	first := s.refreshIntervalFor("cli/cli")
+	for i := 0; i < 10; i++ {
+		require.Equal(t, first, s.refreshIntervalFor("cli/cli"), "repeated calls for the same repo must agree on the threshold")
+	}
+
+	require.GreaterOrEqual(t, first, time.Minute)
+	require.Less(t, first, time.Minute+time.Minute/4)
+}
@@ -0,0 +1,314 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cli/cli/v2/api"
+	"github.com/cli/cli/v2/internal/browser"
+	"github.com/cli/cli/v2/internal/ghrepo"
+	"github.com/cli/cli/v2/pkg/cmd/pr/shared"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+type ChecksOptions struct {
+	IO      *iostreams.IOStreams
+	Browser browser.Browser
+	Finder  shared.PRFinder
+
+	SelectorArg string
+	WebMode     bool
+	Watch       bool
+	Interval    time.Duration
+}
+
+func NewCmdChecks(f *cmdutil.Factory, runF func(*ChecksOptions) error) *cobra.Command {
+	opts := &ChecksOptions{
+		IO:      f.IOStreams,
+		Browser: f.Browser,
+	}
+
+	var intervalSeconds int
+	cmd := &cobra.Command{
+		Use:   "checks [<number> | <url> | <branch>]",
+		Short: "Show CI status for a single pull request",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Finder = shared.NewFinder(f)
+
+			if len(args) > 0 {
+				opts.SelectorArg = args[0]
+			}
+
+			opts.Interval = time.Duration(intervalSeconds) * time.Second
+			if !opts.Watch && cmd.Flags().Changed("interval") {
+				return cmdutil.FlagErrorf("cannot use `--interval` flag without `--watch` flag")
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return checksRun(opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.WebMode, "web", "w", false, "Open the web browser to show details about checks")
+	cmd.Flags().BoolVar(&opts.Watch, "watch", false, "Watch checks until they finish")
+	cmd.Flags().IntVar(&intervalSeconds, "interval", 10, "Refresh interval in seconds when using `--watch` flag")
+
+	cmd.AddCommand(NewCmdCheckLogs(f, nil))
+
+	return cmd
+}
+
+func checksRunWebMode(opts *ChecksOptions) error {
+	findOptions := shared.FindOptions{
+		Selector: opts.SelectorArg,
+		Fields:   []string{"number"},
+	}
+	pr, baseRepo, err := opts.Finder.Find(findOptions)
+	if err != nil {
+		return err
+	}
+
+	checksURL := ghrepo.GenerateRepoURL(baseRepo, "pull/%d/checks", pr.Number)
+
+	if opts.IO.IsStdoutTTY() {
+		fmt.Fprintf(opts.IO.ErrOut, "Opening %s/%s/pull/%d/checks in your browser.\n", baseRepo.RepoHost(), ghrepo.FullName(baseRepo), pr.Number)
+	}
+
+	return opts.Browser.Browse(checksURL)
+}
+
+func checksRun(opts *ChecksOptions) error {
+	if opts.WebMode {
+		return checksRunWebMode(opts)
+	}
+
+	findOptions := shared.FindOptions{
+		Selector: opts.SelectorArg,
+		Fields:   []string{"number", "headRefName", "statusCheckRollup"},
+	}
+	pr, _, err := opts.Finder.Find(findOptions)
+	if err != nil {
+		return err
+	}
+
+	if len(pr.StatusCheckRollup.Nodes) == 0 {
+		return fmt.Errorf("no commit found on the pull request")
+	}
+
+	rollup := pr.StatusCheckRollup.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes
+	if len(rollup) == 0 {
+		return fmt.Errorf("no checks reported on the '%s' branch", pr.HeadRefName)
+	}
+
+	var summary checksSummary
+	for {
+		summary = summarizeChecks(eliminateDuplicates(rollup))
+
+		if opts.Watch && opts.IO.IsStdoutTTY() {
+			opts.IO.StartAlternateScreenBuffer()
+		}
+		if err := printSummary(opts.IO, summary); err != nil {
+			return err
+		}
+		if opts.Watch && opts.IO.IsStdoutTTY() {
+			opts.IO.StopAlternateScreenBuffer()
+		}
+
+		if !opts.Watch || summary.pending == 0 {
+			if opts.Watch {
+				// reprint outside the alternate screen so the final frame
+				// is left behind on the normal terminal once watching ends
+				if err := printSummary(opts.IO, summary); err != nil {
+					return err
+				}
+			}
+			break
+		}
+
+		time.Sleep(opts.Interval)
+
+		pr, _, err = opts.Finder.Find(findOptions)
+		if err != nil {
+			return err
+		}
+		rollup = pr.StatusCheckRollup.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes
+	}
+
+	if summary.failing > 0 || summary.pending > 0 {
+		return fmt.Errorf("SilentError")
+	}
+
+	return nil
+}
+
+type checkRow struct {
+	Name    string
+	Bucket  string
+	Elapsed time.Duration
+	Link    string
+}
+
+type checksSummary struct {
+	rows     []checkRow
+	failing  int
+	pending  int
+	skipping int
+	passing  int
+}
+
+func bucketFor(c api.CheckContext) string {
+	state := c.State
+	if c.TypeName == "CheckRun" {
+		if c.Status != "COMPLETED" {
+			return "pending"
+		}
+		state = c.Conclusion
+	}
+
+	switch state {
+	case "SUCCESS", "NEUTRAL":
+		return "pass"
+	case "SKIPPED":
+		return "skipping"
+	case "PENDING", "EXPECTED", "":
+		return "pending"
+	default:
+		return "fail"
+	}
+}
+
+func linkFor(c api.CheckContext) string {
+	if c.DetailsURL != "" {
+		return c.DetailsURL
+	}
+	return c.TargetURL
+}
+
+func nameFor(c api.CheckContext) string {
+	if c.TypeName == "StatusContext" {
+		return c.Context
+	}
+	return c.Name
+}
+
+func summarizeChecks(contexts []api.CheckContext) checksSummary {
+	var summary checksSummary
+	for _, c := range contexts {
+		bucket := bucketFor(c)
+		var elapsed time.Duration
+		if !c.StartedAt.IsZero() && !c.CompletedAt.IsZero() {
+			elapsed = c.CompletedAt.Sub(c.StartedAt)
+		}
+
+		summary.rows = append(summary.rows, checkRow{
+			Name:    nameFor(c),
+			Bucket:  bucket,
+			Elapsed: elapsed,
+			Link:    linkFor(c),
+		})
+
+		switch bucket {
+		case "fail":
+			summary.failing++
+		case "pending":
+			summary.pending++
+		case "skipping":
+			summary.skipping++
+		default:
+			summary.passing++
+		}
+	}
+
+	priority := map[string]int{"fail": 0, "pass": 1, "skipping": 2, "pending": 3}
+	sort.SliceStable(summary.rows, func(i, j int) bool {
+		return priority[summary.rows[i].Bucket] < priority[summary.rows[j].Bucket]
+	})
+
+	return summary
+}
+
+func printSummary(io *iostreams.IOStreams, summary checksSummary) error {
+	cs := io.ColorScheme()
+
+	if summary.failing > 0 {
+		fmt.Fprintln(io.Out, "Some checks were not successful")
+	} else if summary.pending > 0 {
+		fmt.Fprintln(io.Out, "Some checks are still pending")
+	} else {
+		fmt.Fprintln(io.Out, "All checks were successful")
+	}
+
+	fmt.Fprintf(io.Out, "%d failing, %d successful, %d skipped, and %d pending checks\n\n",
+		summary.failing, summary.passing, summary.skipping, summary.pending)
+
+	if io.IsStdoutTTY() {
+		tp := tabwriter.NewWriter(io.Out, 0, 0, 2, ' ', 0)
+		for _, row := range summary.rows {
+			elapsed := ""
+			if row.Elapsed > 0 {
+				elapsed = row.Elapsed.String()
+			}
+			fmt.Fprintf(tp, "%s\t%s\t%s\t%s\n", icon(cs, row.Bucket), row.Name, elapsed, row.Link)
+		}
+		return tp.Flush()
+	}
+
+	for _, row := range summary.rows {
+		elapsed := "0"
+		if row.Elapsed > 0 {
+			elapsed = row.Elapsed.String()
+		}
+		fmt.Fprintf(io.Out, "%s\t%s\t%s\t%s\n", row.Name, row.Bucket, elapsed, row.Link)
+	}
+	return nil
+}
+
+func icon(cs *iostreams.ColorScheme, bucket string) string {
+	switch bucket {
+	case "pass":
+		return cs.SuccessIcon()
+	case "fail":
+		return cs.Red("X")
+	case "skipping":
+		return cs.Gray("-")
+	default:
+		return cs.Yellow("*")
+	}
+}
+
+// eliminateDuplicates keeps only the most recently started check for each
+// unique check name/status context, sorted with the most recent first.
+func eliminateDuplicates(checkContexts []api.CheckContext) []api.CheckContext {
+	mapChecks := make(map[string]int)
+	var result []api.CheckContext
+
+	for _, context := range checkContexts {
+		name := context.Name
+		if context.TypeName == "StatusContext" {
+			name = context.Context
+		}
+
+		if idx, ok := mapChecks[name]; ok {
+			if context.StartedAt.After(result[idx].StartedAt) {
+				result[idx] = context
+			}
+			continue
+		}
+
+		mapChecks[name] = len(result)
+		result = append(result, context)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartedAt.After(result[j].StartedAt)
+	})
+
+	return result
+}
@@ -0,0 +1,100 @@
+package checks
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+)
+
+// defaultFlushInterval bounds how long a partial (newline-less) line can sit
+// in the buffer before it's flushed anyway, so a slow-writing job doesn't
+// leave `checks logs --watch` looking stalled.
+const defaultFlushInterval = 500 * time.Millisecond
+
+// LineWriter batches writes and flushes them to Out a line at a time,
+// falling back to a timer so streamed output stays responsive even when a
+// line doesn't end in a timely newline.
+type LineWriter struct {
+	Out           io.Writer
+	FlushInterval time.Duration
+
+	mu    sync.Mutex
+	buf   bytes.Buffer
+	timer *time.Timer
+}
+
+// NewLineWriter returns a LineWriter that flushes complete lines to out
+// immediately, and any remaining partial line after flushInterval of
+// inactivity.
+func NewLineWriter(out io.Writer, flushInterval time.Duration) *LineWriter {
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	return &LineWriter{Out: out, FlushInterval: flushInterval}
+}
+
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n, err := w.buf.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	if err := w.flushLines(); err != nil {
+		return n, err
+	}
+
+	w.resetTimer()
+	return n, nil
+}
+
+// flushLines writes out every complete line currently buffered, leaving any
+// trailing partial line in place. Callers must hold w.mu.
+func (w *LineWriter) flushLines() error {
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// not a full line yet; put it back for next time
+			w.buf.Reset()
+			w.buf.WriteString(line)
+			return nil
+		}
+		if _, err := io.WriteString(w.Out, line); err != nil {
+			return err
+		}
+	}
+}
+
+func (w *LineWriter) resetTimer() {
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.FlushInterval, func() { _ = w.Flush() })
+		return
+	}
+	w.timer.Reset(w.FlushInterval)
+}
+
+// Flush writes out any buffered partial line immediately.
+func (w *LineWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := io.WriteString(w.Out, w.buf.String())
+	w.buf.Reset()
+	return err
+}
+
+// Close flushes any remaining buffered output and stops the flush timer.
+func (w *LineWriter) Close() error {
+	w.mu.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+	return w.Flush()
+}
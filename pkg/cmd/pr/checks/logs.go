@@ -0,0 +1,215 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/cli/v2/api"
+	"github.com/cli/cli/v2/internal/ghrepo"
+	"github.com/cli/cli/v2/pkg/cmd/pr/shared"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// defaultMaxLogBytes caps how much of a single job's log is streamed to the
+// terminal, so a runaway or looping job can't flood `checks logs --watch`.
+const defaultMaxLogBytes = 2 * 1024 * 1024 // 2 MiB
+
+// pollInterval is how often checksLogsRun re-checks for in-progress jobs
+// while --watch is set.
+const pollInterval = 5 * time.Second
+
+type ChecksLogsOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	Finder     shared.PRFinder
+
+	SelectorArg string
+	Watch       bool
+	MaxLogBytes int64
+	Redact      []string
+}
+
+// NewCmdCheckLogs returns the `gh pr checks logs` command, which tails the
+// logs of a pull request's in-progress check runs.
+func NewCmdCheckLogs(f *cmdutil.Factory, runF func(*ChecksLogsOptions) error) *cobra.Command {
+	opts := &ChecksLogsOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+	}
+
+	var maxLogBytes int64
+	cmd := &cobra.Command{
+		Use:   "logs [<number> | <url> | <branch>]",
+		Short: "View logs for a pull request's check runs",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Finder = shared.NewFinder(f)
+
+			if len(args) > 0 {
+				opts.SelectorArg = args[0]
+			}
+			opts.MaxLogBytes = maxLogBytes
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return checksLogsRun(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Watch, "watch", false, "Keep tailing logs for in-progress jobs")
+	cmd.Flags().Int64Var(&maxLogBytes, "max-log-bytes", defaultMaxLogBytes, "Maximum bytes of log output to stream per job")
+	cmd.Flags().StringArrayVar(&opts.Redact, "redact", nil, "Secret substring to mask in streamed output (can be used multiple times)")
+
+	return cmd
+}
+
+// jobLogFetcher fetches the raw log stream for an in-progress check run. A
+// function value so tests can stub out the Actions logs API.
+type jobLogFetcher func(ctx context.Context, httpClient *http.Client, repo ghrepo.Interface, jobID int64) (io.ReadCloser, error)
+
+var fetchJobLog jobLogFetcher = fetchJobLogFromAPI
+
+func checksLogsRun(opts *ChecksLogsOptions) error {
+	findOptions := shared.FindOptions{
+		Selector: opts.SelectorArg,
+		Fields:   []string{"number", "statusCheckRollup"},
+	}
+	pr, baseRepo, err := opts.Finder.Find(findOptions)
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return err
+	}
+
+	offsets := map[int64]int64{}
+
+	for {
+		jobs := inProgressJobs(pr)
+		if len(jobs) == 0 {
+			if opts.Watch {
+				return nil
+			}
+			return fmt.Errorf("no in-progress checks found")
+		}
+
+		for _, job := range jobs {
+			if offsets[job.ID] >= opts.MaxLogBytes {
+				continue
+			}
+			n, err := streamJobLog(opts, httpClient, baseRepo, job, offsets[job.ID])
+			if err != nil {
+				return err
+			}
+			offsets[job.ID] += n
+		}
+
+		if !opts.Watch {
+			return nil
+		}
+
+		time.Sleep(pollInterval)
+		pr, _, err = opts.Finder.Find(findOptions)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type inProgressJob struct {
+	ID   int64
+	Name string
+}
+
+// inProgressJobs extracts the still-running check runs from pr, deriving
+// each job's numeric id from its details URL since the GraphQL check
+// context doesn't expose one directly.
+func inProgressJobs(pr *api.PullRequest) []inProgressJob {
+	if len(pr.StatusCheckRollup.Nodes) == 0 {
+		return nil
+	}
+
+	var jobs []inProgressJob
+	for _, c := range pr.StatusCheckRollup.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+		if c.TypeName != "CheckRun" || c.Status == "COMPLETED" {
+			continue
+		}
+		id, ok := jobIDFromURL(c.DetailsURL)
+		if !ok {
+			continue
+		}
+		jobs = append(jobs, inProgressJob{ID: id, Name: c.Name})
+	}
+	return jobs
+}
+
+// jobIDFromURL pulls the trailing numeric segment off a check-run details
+// URL, e.g. https://github.com/OWNER/REPO/actions/runs/1/job/2 -> 2.
+func jobIDFromURL(detailsURL string) (int64, bool) {
+	idx := strings.LastIndex(detailsURL, "/")
+	if idx < 0 {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(detailsURL[idx+1:], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// streamJobLog fetches job's current log, skips the bytes already streamed
+// in a previous poll (tracked via alreadyWritten), and writes only the new
+// tail to opts.IO.Out, up to opts.MaxLogBytes total. It returns the number
+// of new bytes written so the caller can advance its offset for the job.
+func streamJobLog(opts *ChecksLogsOptions, httpClient *http.Client, repo ghrepo.Interface, job inProgressJob, alreadyWritten int64) (int64, error) {
+	body, err := fetchJobLog(context.Background(), httpClient, repo, job.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch logs for %q: %w", job.Name, err)
+	}
+	defer body.Close()
+
+	if _, err := io.CopyN(io.Discard, body, alreadyWritten); err != nil && err != io.EOF {
+		return 0, err
+	}
+
+	redacted := newRedactWriter(opts.IO.Out, opts.Redact)
+	lw := NewLineWriter(redacted, defaultFlushInterval)
+	defer lw.Close()
+
+	remaining := opts.MaxLogBytes - alreadyWritten
+	if remaining <= 0 {
+		return 0, nil
+	}
+	limited := io.LimitReader(body, remaining)
+	n, err := io.Copy(lw, limited)
+	return n, err
+}
+
+func fetchJobLogFromAPI(ctx context.Context, httpClient *http.Client, repo ghrepo.Interface, jobID int64) (io.ReadCloser, error) {
+	url := fmt.Sprintf("https://api.%s/repos/%s/actions/jobs/%d/logs", repo.RepoHost(), ghrepo.FullName(repo), jobID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status fetching job logs: %s", resp.Status)
+	}
+	return resp.Body, nil
+}
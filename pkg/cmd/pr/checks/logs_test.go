@@ -0,0 +1,87 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/cli/v2/api"
+	"github.com/cli/cli/v2/internal/ghrepo"
+	"github.com/cli/cli/v2/pkg/cmd/pr/shared"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubJobLog(t *testing.T, body string) {
+	t.Helper()
+	orig := fetchJobLog
+	fetchJobLog = func(ctx context.Context, httpClient *http.Client, repo ghrepo.Interface, jobID int64) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(body)), nil
+	}
+	t.Cleanup(func() { fetchJobLog = orig })
+}
+
+func inProgressPR(t *testing.T) *api.PullRequest {
+	t.Helper()
+	const fixture = `{
+		"number": 123,
+		"headRefName": "master",
+		"statusCheckRollup": {
+			"nodes": [{
+				"commit": {
+					"oid": "abc",
+					"statusCheckRollup": {
+						"contexts": {
+							"nodes": [{
+								"__typename": "CheckRun",
+								"name": "build",
+								"status": "IN_PROGRESS",
+								"detailsUrl": "https://github.com/OWNER/REPO/actions/runs/1/job/42"
+							}]
+						}
+					}
+				}
+			}]
+		}
+	}`
+
+	var pr *api.PullRequest
+	require.NoError(t, json.Unmarshal([]byte(fixture), &pr))
+	return pr
+}
+
+func TestChecksLogsRun_redactsSecrets(t *testing.T) {
+	stubJobLog(t, "line one sk-secret123\nline two\n")
+
+	ios, _, stdout, _ := iostreams.Test()
+	opts := &ChecksLogsOptions{
+		IO:          ios,
+		HttpClient:  func() (*http.Client, error) { return &http.Client{}, nil },
+		Finder:      shared.NewMockFinder("123", inProgressPR(t), ghrepo.New("OWNER", "REPO")),
+		MaxLogBytes: defaultMaxLogBytes,
+		Redact:      []string{"sk-secret123"},
+	}
+
+	require.NoError(t, checksLogsRun(opts))
+	assert.Contains(t, stdout.String(), redactedPlaceholder)
+	assert.NotContains(t, stdout.String(), "sk-secret123")
+}
+
+func TestChecksLogsRun_truncatesAtMaxLogBytes(t *testing.T) {
+	stubJobLog(t, strings.Repeat("a", 100))
+
+	ios, _, stdout, _ := iostreams.Test()
+	opts := &ChecksLogsOptions{
+		IO:          ios,
+		HttpClient:  func() (*http.Client, error) { return &http.Client{}, nil },
+		Finder:      shared.NewMockFinder("123", inProgressPR(t), ghrepo.New("OWNER", "REPO")),
+		MaxLogBytes: 10,
+	}
+
+	require.NoError(t, checksLogsRun(opts))
+	assert.Equal(t, 10, len(stdout.String()))
+}
@@ -0,0 +1,40 @@
+package checks
+
+import (
+	"io"
+	"strings"
+)
+
+const redactedPlaceholder = "████████"
+
+// redactWriter rewrites every occurrence of a caller-supplied secret with a
+// placeholder before forwarding the text to Out, so watched log output
+// can't leak values like repo secrets used by the check run.
+type redactWriter struct {
+	Out     io.Writer
+	secrets []string
+}
+
+// newRedactWriter returns a writer that masks any of secrets before writing
+// to out. Empty strings are ignored so an unset secret can't redact
+// everything.
+func newRedactWriter(out io.Writer, secrets []string) *redactWriter {
+	filtered := secrets[:0:0]
+	for _, s := range secrets {
+		if s != "" {
+			filtered = append(filtered, s)
+		}
+	}
+	return &redactWriter{Out: out, secrets: filtered}
+}
+
+func (w *redactWriter) Write(p []byte) (int, error) {
+	s := string(p)
+	for _, secret := range w.secrets {
+		s = strings.ReplaceAll(s, secret, redactedPlaceholder)
+	}
+	if _, err := io.WriteString(w.Out, s); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}